@@ -0,0 +1,124 @@
+/*
+Copyright 2021 Loggie Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FederatedOwnerLabel is set on every LogConfig/ClusterLogConfig a
+// Federated{,Cluster}LogConfig materializes, so the controller can find and
+// garbage-collect them without needing a cross-cluster ownerReference.
+const FederatedOwnerLabel = "loggie.io/federated-owner"
+
+// LogConfigTemplate is the LogConfigSpec a Federated{,Cluster}LogConfig
+// stamps out in every placed cluster, before cluster-specific Overrides.
+type LogConfigTemplate struct {
+	Metadata metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec     LogConfigSpec     `json:"spec"`
+}
+
+// Placement selects which clusters a federated config is materialized into,
+// either by explicit name or by a label selector. The selector is matched
+// against the single label cluster=<cluster name>; no other cluster labels
+// are known to the controller.
+type Placement struct {
+	Clusters        []string              `json:"clusters,omitempty"`
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+}
+
+// Override is a JSON-patch entry applied to the materialized LogConfig in
+// one specific cluster, eg to point sink.addr at that environment's broker.
+type Override struct {
+	ClusterName string `json:"clusterName"`
+	// Path is a JSON-patch path into the materialized LogConfig/
+	// ClusterLogConfigSpec itself (not the whole object), eg "/pipeline".
+	Path string `json:"path"`
+	// Value is the JSON-patch replacement value, as a raw JSON string so it
+	// can hold scalars, objects or arrays without a second CRD field per type.
+	Value string `json:"value"`
+}
+
+type FederatedLogConfigSpec struct {
+	Template  LogConfigTemplate `json:"template"`
+	Placement Placement         `json:"placement"`
+	Overrides []Override        `json:"overrides,omitempty"`
+}
+
+type FederatedLogConfigStatus struct {
+	// MaterializedClusters is the set of clusters this object currently has
+	// a child LogConfig materialized in.
+	MaterializedClusters []string    `json:"materializedClusters,omitempty"`
+	Conditions           []Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FederatedLogConfig distributes one LogConfigTemplate to many clusters with
+// per-cluster overrides, so users manage a single CR per pipeline instead of
+// hand-maintaining N near-identical LogConfigs.
+type FederatedLogConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FederatedLogConfigSpec   `json:"spec,omitempty"`
+	Status FederatedLogConfigStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type FederatedLogConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []FederatedLogConfig `json:"items"`
+}
+
+type FederatedClusterLogConfigSpec struct {
+	Template  LogConfigTemplate `json:"template"`
+	Placement Placement         `json:"placement"`
+	Overrides []Override        `json:"overrides,omitempty"`
+}
+
+type FederatedClusterLogConfigStatus struct {
+	MaterializedClusters []string    `json:"materializedClusters,omitempty"`
+	Conditions           []Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FederatedClusterLogConfig is the ClusterLogConfig counterpart of
+// FederatedLogConfig.
+type FederatedClusterLogConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FederatedClusterLogConfigSpec   `json:"spec,omitempty"`
+	Status FederatedClusterLogConfigStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type FederatedClusterLogConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []FederatedClusterLogConfig `json:"items"`
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 Loggie Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionType is the type of a LogConfig/ClusterLogConfig status condition.
+type ConditionType string
+
+const (
+	// ConditionReady means the pipeline derived from this config is rendered
+	// and running on every matched resource.
+	ConditionReady ConditionType = "Ready"
+	// ConditionProgressing means the controller is still reconciling matched
+	// resources, eg. right after the selector changed.
+	ConditionProgressing ConditionType = "Progressing"
+	// ConditionDegraded means the last reconcile failed, see Reason/Message.
+	ConditionDegraded ConditionType = "Degraded"
+)
+
+// Condition is a loggie-local copy of the standard condition shape used
+// across the Kubernetes API so status consumers don't need extra imports.
+type Condition struct {
+	Type               ConditionType          `json:"type"`
+	Status             corev1.ConditionStatus `json:"status"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime,omitempty"`
+}
+
+// MatchedResource identifies one thing on this loggie node that a
+// LogConfig/ClusterLogConfig selector matched and a pipeline was rendered for.
+type MatchedResource struct {
+	// Kind is one of Pod, Node, Vm.
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// UID is the pod UID for Kind=Pod, empty otherwise.
+	UID string `json:"uid,omitempty"`
+}
+
+// LogConfigStatus is written back to the CR after every syncHandler pass for
+// this LogConfig, so `kubectl get logconfig` shows what it actually did.
+type LogConfigStatus struct {
+	// ObservedGeneration is the .metadata.generation last acted on.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// MatchedResources lists what this loggie node applied the pipeline to.
+	MatchedResources []MatchedResource `json:"matchedResources,omitempty"`
+
+	// PipelineConfigHash is a hash of the raw Spec.Pipeline, so operators can
+	// tell whether the spec a node last reconciled against has changed. It is
+	// not a hash of the per-node rendered pipeline, so it does not show two
+	// nodes diverging after field-pattern templating.
+	PipelineConfigHash string `json:"pipelineConfigHash,omitempty"`
+
+	LastReconcileTime metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// ClusterLogConfigStatus mirrors LogConfigStatus; ClusterLogConfig reconciles
+// through the same code path via ToLogConfig.
+type ClusterLogConfigStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	MatchedResources []MatchedResource `json:"matchedResources,omitempty"`
+
+	PipelineConfigHash string `json:"pipelineConfigHash,omitempty"`
+
+	LastReconcileTime metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// VmStatus reports whether the Loggie agent is alive on this Vm, so an
+// operator can tell "no Loggie installed" apart from "Loggie crashed".
+type VmStatus struct {
+	// LastHeartbeatTime is bumped every time this Vm's coordination.k8s.io
+	// Lease is successfully renewed.
+	LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime,omitempty"`
+
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// SinkStatus reports whether this Sink is currently referenced and usable
+// by at least one rendered pipeline on this loggie node.
+type SinkStatus struct {
+	ObservedGeneration int64       `json:"observedGeneration,omitempty"`
+	Conditions         []Condition `json:"conditions,omitempty"`
+}
+
+// InterceptorStatus is the Interceptor counterpart of SinkStatus.
+type InterceptorStatus struct {
+	ObservedGeneration int64       `json:"observedGeneration,omitempty"`
+	Conditions         []Condition `json:"conditions,omitempty"`
+}
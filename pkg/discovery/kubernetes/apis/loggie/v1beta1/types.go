@@ -0,0 +1,180 @@
+/*
+Copyright 2021 Loggie Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SelectorTypePod      = "pod"
+	SelectorTypeNode     = "node"
+	SelectorTypeWorkload = "workload"
+	SelectorTypeAll      = "all"
+)
+
+// WorkloadSelector locates pods indirectly via the workload that owns them,
+// so users don't have to duplicate the workload's label selector by hand.
+type WorkloadSelector struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type NodeSelector struct {
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+type Selector struct {
+	Cluster string `json:"cluster,omitempty"`
+	Type    string `json:"type,omitempty"`
+
+	LabelSelector map[string]string `json:"labelSelector,omitempty"`
+	NodeSelector  *NodeSelector      `json:"nodeSelector,omitempty"`
+	Workload      *WorkloadSelector  `json:"workload,omitempty"`
+}
+
+type LogConfigSpec struct {
+	Selector *Selector `json:"selector,omitempty"`
+	Pipeline string    `json:"pipeline,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type LogConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LogConfigSpec   `json:"spec,omitempty"`
+	Status LogConfigStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type LogConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []LogConfig `json:"items"`
+}
+
+type ClusterLogConfigSpec struct {
+	Selector *Selector `json:"selector,omitempty"`
+	Pipeline string    `json:"pipeline,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ClusterLogConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterLogConfigSpec   `json:"spec,omitempty"`
+	Status ClusterLogConfigStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ClusterLogConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterLogConfig `json:"items"`
+}
+
+// ToLogConfig converts a ClusterLogConfig into the LogConfig shape the
+// controller reconciles, since both share the same selector/pipeline semantics.
+func (c *ClusterLogConfig) ToLogConfig() *LogConfig {
+	return &LogConfig{
+		TypeMeta:   c.TypeMeta,
+		ObjectMeta: c.ObjectMeta,
+		Spec: LogConfigSpec{
+			Selector: c.Spec.Selector,
+			Pipeline: c.Spec.Pipeline,
+		},
+	}
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type Sink struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Sink   string     `json:"sink,omitempty"`
+	Status SinkStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type SinkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Sink `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type Interceptor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Interceptor string            `json:"interceptor,omitempty"`
+	Status      InterceptorStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type InterceptorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Interceptor `json:"items"`
+}
+
+// VmSpec describes a virtual machine running the Loggie agent outside of
+// Kubernetes, reconciled the same way as a Node in pod mode.
+type VmSpec struct {
+	Hostname string `json:"hostname,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type Vm struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VmSpec   `json:"spec,omitempty"`
+	Status VmStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VmList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Vm `json:"items"`
+}
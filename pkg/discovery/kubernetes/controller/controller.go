@@ -22,7 +22,6 @@ import (
 	"github.com/loggie-io/loggie/pkg/discovery/kubernetes/runtime"
 	"github.com/loggie-io/loggie/pkg/util/pattern"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"reflect"
 	"time"
 
 	"github.com/loggie-io/loggie/pkg/core/log"
@@ -30,6 +29,8 @@ import (
 	logconfigSchema "github.com/loggie-io/loggie/pkg/discovery/kubernetes/client/clientset/versioned/scheme"
 	logconfigInformers "github.com/loggie-io/loggie/pkg/discovery/kubernetes/client/informers/externalversions/loggie/v1beta1"
 	logconfigLister "github.com/loggie-io/loggie/pkg/discovery/kubernetes/client/listers/loggie/v1beta1"
+	"github.com/loggie-io/loggie/pkg/discovery/kubernetes/controller/handlers"
+	"github.com/loggie-io/loggie/pkg/discovery/kubernetes/controller/predicates"
 	"github.com/loggie-io/loggie/pkg/discovery/kubernetes/helper"
 	"github.com/loggie-io/loggie/pkg/discovery/kubernetes/index"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -42,20 +43,25 @@ import (
 	"k8s.io/client-go/tools/record"
 
 	logconfigv1beta1 "github.com/loggie-io/loggie/pkg/discovery/kubernetes/apis/loggie/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	appsv1Informers "k8s.io/client-go/informers/apps/v1"
 	corev1Informers "k8s.io/client-go/informers/core/v1"
+	appsv1Listers "k8s.io/client-go/listers/apps/v1"
 	corev1Listers "k8s.io/client-go/listers/core/v1"
 )
 
 const (
-	EventPod            = "pod"
-	EventLogConf        = "logConfig"
-	EventNode           = "node"
-	EventVm             = "vm"
-	EventClusterLogConf = "clusterLogConfig"
-	EventSink           = "sink"
-	EventInterceptor    = "interceptor"
+	EventPod                     = "pod"
+	EventLogConf                 = "logConfig"
+	EventNode                    = "node"
+	EventVm                      = "vm"
+	EventClusterLogConf          = "clusterLogConfig"
+	EventSink                    = "sink"
+	EventInterceptor             = "interceptor"
+	EventFederatedLogConf        = "federatedLogConfig"
+	EventFederatedClusterLogConf = "federatedClusterLogConfig"
 
 	InjectorAnnotationKey       = "sidecar.loggie.io/inject"
 	InjectorAnnotationValueTrue = "true"
@@ -75,19 +81,27 @@ type Controller struct {
 	kubeClientset      kubernetes.Interface
 	logConfigClientset logconfigClientset.Interface
 
-	podsLister             corev1Listers.PodLister
-	logConfigLister        logconfigLister.LogConfigLister
-	clusterLogConfigLister logconfigLister.ClusterLogConfigLister
-	sinkLister             logconfigLister.SinkLister
-	interceptorLister      logconfigLister.InterceptorLister
-	nodeLister             corev1Listers.NodeLister
+	podsLister                      corev1Listers.PodLister
+	logConfigLister                 logconfigLister.LogConfigLister
+	clusterLogConfigLister          logconfigLister.ClusterLogConfigLister
+	sinkLister                      logconfigLister.SinkLister
+	interceptorLister               logconfigLister.InterceptorLister
+	nodeLister                      corev1Listers.NodeLister
+	federatedLogConfigLister        logconfigLister.FederatedLogConfigLister
+	federatedClusterLogConfigLister logconfigLister.FederatedClusterLogConfigLister
+
+	deploymentLister  appsv1Listers.DeploymentLister
+	statefulSetLister appsv1Listers.StatefulSetLister
+	daemonSetLister   appsv1Listers.DaemonSetLister
+	replicaSetLister  appsv1Listers.ReplicaSetLister
 
 	// only in Vm mode
 	vmLister logconfigLister.VmLister
 
-	typePodIndex     *index.LogConfigTypePodIndex
-	typeClusterIndex *index.LogConfigTypeClusterIndex
-	typeNodeIndex    *index.LogConfigTypeNodeIndex
+	typePodIndex      *index.LogConfigTypePodIndex
+	typeClusterIndex  *index.LogConfigTypeClusterIndex
+	typeNodeIndex     *index.LogConfigTypeNodeIndex
+	typeWorkloadIndex *index.LogConfigTypeWorkloadIndex
 
 	nodeInfo *corev1.Node
 	vmInfo   *logconfigv1beta1.Vm
@@ -97,6 +111,9 @@ type Controller struct {
 	extraTypePodFieldsPattern  map[string]*pattern.Pattern
 	extraTypeNodeFieldsPattern map[string]*pattern.Pattern
 	extraTypeVmFieldsPattern   map[string]*pattern.Pattern
+
+	lease *leaseController
+	gc    *gcController
 }
 
 func NewController(
@@ -110,6 +127,12 @@ func NewController(
 	interceptorInformer logconfigInformers.InterceptorInformer,
 	nodeInformer corev1Informers.NodeInformer,
 	vmInformer logconfigInformers.VmInformer,
+	federatedLogConfigInformer logconfigInformers.FederatedLogConfigInformer,
+	federatedClusterLogConfigInformer logconfigInformers.FederatedClusterLogConfigInformer,
+	deploymentInformer appsv1Informers.DeploymentInformer,
+	statefulSetInformer appsv1Informers.StatefulSetInformer,
+	daemonSetInformer appsv1Informers.DaemonSetInformer,
+	replicaSetInformer appsv1Informers.ReplicaSetInformer,
 	runtime runtime.Runtime,
 ) *Controller {
 
@@ -127,10 +150,12 @@ func NewController(
 			kubeClientset:      kubeClientset,
 			logConfigClientset: logConfigClientset,
 
-			clusterLogConfigLister: clusterLogConfigInformer.Lister(),
-			sinkLister:             sinkInformer.Lister(),
-			interceptorLister:      interceptorInformer.Lister(),
-			vmLister:               vmInformer.Lister(),
+			clusterLogConfigLister:          clusterLogConfigInformer.Lister(),
+			sinkLister:                      sinkInformer.Lister(),
+			interceptorLister:               interceptorInformer.Lister(),
+			vmLister:                        vmInformer.Lister(),
+			federatedLogConfigLister:        federatedLogConfigInformer.Lister(),
+			federatedClusterLogConfigLister: federatedClusterLogConfigInformer.Lister(),
 
 			typeNodeIndex: index.NewLogConfigTypeNodeIndex(),
 
@@ -144,16 +169,24 @@ func NewController(
 			kubeClientset:      kubeClientset,
 			logConfigClientset: logConfigClientset,
 
-			podsLister:             podInformer.Lister(),
-			logConfigLister:        logConfigInformer.Lister(),
-			clusterLogConfigLister: clusterLogConfigInformer.Lister(),
-			sinkLister:             sinkInformer.Lister(),
-			interceptorLister:      interceptorInformer.Lister(),
-			nodeLister:             nodeInformer.Lister(),
-
-			typePodIndex:     index.NewLogConfigTypePodIndex(),
-			typeClusterIndex: index.NewLogConfigTypeLoggieIndex(),
-			typeNodeIndex:    index.NewLogConfigTypeNodeIndex(),
+			podsLister:                      podInformer.Lister(),
+			logConfigLister:                 logConfigInformer.Lister(),
+			clusterLogConfigLister:          clusterLogConfigInformer.Lister(),
+			sinkLister:                      sinkInformer.Lister(),
+			interceptorLister:               interceptorInformer.Lister(),
+			nodeLister:                      nodeInformer.Lister(),
+			federatedLogConfigLister:        federatedLogConfigInformer.Lister(),
+			federatedClusterLogConfigLister: federatedClusterLogConfigInformer.Lister(),
+
+			deploymentLister:  deploymentInformer.Lister(),
+			statefulSetLister: statefulSetInformer.Lister(),
+			daemonSetLister:   daemonSetInformer.Lister(),
+			replicaSetLister:  replicaSetInformer.Lister(),
+
+			typePodIndex:      index.NewLogConfigTypePodIndex(),
+			typeClusterIndex:  index.NewLogConfigTypeLoggieIndex(),
+			typeNodeIndex:     index.NewLogConfigTypeNodeIndex(),
+			typeWorkloadIndex: index.NewLogConfigTypeWorkloadIndex(),
 
 			record:  recorder,
 			runtime: runtime,
@@ -161,6 +194,8 @@ func NewController(
 	}
 
 	controller.InitK8sFieldsPattern()
+	controller.lease = newLeaseController(controller)
+	controller.gc = newGCController(controller)
 
 	log.Info("Setting up event handlers")
 	utilruntime.Must(logconfigSchema.AddToScheme(scheme.Scheme))
@@ -181,193 +216,89 @@ func NewController(
 		controller.nodeInfo = node.DeepCopy()
 	}
 
-	clusterLogConfigInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			config := obj.(*logconfigv1beta1.ClusterLogConfig)
-			if config.Spec.Selector == nil {
-				return
-			}
-			if !controller.belongOfCluster(config.Spec.Selector.Cluster, config.Annotations) {
-				return
-			}
-
-			controller.enqueue(obj, EventClusterLogConf, config.Spec.Selector.Type)
-		},
-		UpdateFunc: func(new, old interface{}) {
-			newConfig := new.(*logconfigv1beta1.ClusterLogConfig)
-			oldConfig := old.(*logconfigv1beta1.ClusterLogConfig)
-			if newConfig.ResourceVersion == oldConfig.ResourceVersion {
-				return
-			}
-			if newConfig.Generation == oldConfig.Generation {
-				return
-			}
-			if newConfig.Spec.Selector == nil {
-				return
-			}
-			if !controller.belongOfCluster(newConfig.Spec.Selector.Cluster, newConfig.Annotations) {
-				return
-			}
-
-			controller.handleLogConfigSelectorHasChange(newConfig.ToLogConfig(), oldConfig.ToLogConfig())
-			controller.enqueue(new, EventClusterLogConf, newConfig.Spec.Selector.Type)
-		},
-		DeleteFunc: func(obj interface{}) {
-			config, ok := obj.(*logconfigv1beta1.ClusterLogConfig)
-			if !ok {
-				return
-			}
-			if config.Spec.Selector == nil {
-				return
-			}
-			if !controller.belongOfCluster(config.Spec.Selector.Cluster, config.Annotations) {
-				return
-			}
-
-			controller.enqueueForDelete(obj, EventClusterLogConf, config.Spec.Selector.Type)
-		},
-	})
-
-	interceptorInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			controller.enqueue(obj, EventInterceptor, logconfigv1beta1.SelectorTypeAll)
-		},
-		UpdateFunc: func(old, new interface{}) {
-			newConfig := new.(*logconfigv1beta1.Interceptor)
-			oldConfig := old.(*logconfigv1beta1.Interceptor)
-			if newConfig.ResourceVersion == oldConfig.ResourceVersion {
-				return
-			}
-
-			controller.enqueue(new, EventInterceptor, logconfigv1beta1.SelectorTypeAll)
-		},
-	})
+	clusterLogConfigHandler := handlers.EnqueueForType(EventClusterLogConf, clusterLogConfigSelectorType, controller.enqueue, controller.enqueueForDelete)
+	clusterLogConfigHandler.OnUpdate = func(oldObj, newObj metav1.Object) {
+		newConfig := newObj.(*logconfigv1beta1.ClusterLogConfig)
+		oldConfig := oldObj.(*logconfigv1beta1.ClusterLogConfig)
+		controller.handleLogConfigSelectorHasChange(newConfig.ToLogConfig(), oldConfig.ToLogConfig())
+	}
+	clusterLogConfigInformer.Informer().AddEventHandler(handlers.Wrap(clusterLogConfigHandler, predicates.And(
+		predicates.BelongsToCluster{Cluster: config.Cluster, ClusterOf: clusterLogConfigClusterOf, InjectorAnnotationKey: InjectorAnnotationKey},
+		predicates.GenerationChangedPredicate{},
+	)))
 
-	sinkInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			controller.enqueue(obj, EventSink, logconfigv1beta1.SelectorTypeAll)
-		},
-		UpdateFunc: func(old, new interface{}) {
-			newConfig := new.(*logconfigv1beta1.Sink)
-			oldConfig := old.(*logconfigv1beta1.Sink)
-			if newConfig.ResourceVersion == oldConfig.ResourceVersion {
-				return
-			}
+	interceptorHandler := handlers.EnqueueForType(EventInterceptor, allSelectorType, controller.enqueue, nil)
+	interceptorInformer.Informer().AddEventHandler(handlers.Wrap(interceptorHandler, predicates.ResourceVersionChangedPredicate{}))
 
-			controller.enqueue(new, EventSink, logconfigv1beta1.SelectorTypeAll)
-		},
-	})
+	sinkHandler := handlers.EnqueueForType(EventSink, allSelectorType, controller.enqueue, nil)
+	sinkInformer.Informer().AddEventHandler(handlers.Wrap(sinkHandler, predicates.ResourceVersionChangedPredicate{}))
 
 	if config.VmMode {
-		vmInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-			AddFunc: func(obj interface{}) {
-				controller.enqueue(obj, EventVm, logconfigv1beta1.SelectorTypeAll)
-			},
-			UpdateFunc: func(old, new interface{}) {
-				newConfig := new.(*logconfigv1beta1.Vm)
-				oldConfig := old.(*logconfigv1beta1.Vm)
-				if newConfig.ResourceVersion == oldConfig.ResourceVersion {
-					return
-				}
-
-				if reflect.DeepEqual(newConfig.Labels, oldConfig.Labels) {
-					return
-				}
-
-				controller.enqueue(new, EventVm, logconfigv1beta1.SelectorTypeAll)
-			},
-		})
+		vmHandler := handlers.EnqueueForType(EventVm, allSelectorType, controller.enqueue, nil)
+		vmInformer.Informer().AddEventHandler(handlers.Wrap(vmHandler, predicates.And(
+			predicates.ResourceVersionChangedPredicate{},
+			predicates.LabelChangedPredicate{},
+		)))
 
 		return controller
 	}
 
-	logConfigInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			config := obj.(*logconfigv1beta1.LogConfig)
-			if config.Spec.Selector == nil {
-				return
-			}
-			if !controller.belongOfCluster(config.Spec.Selector.Cluster, config.Annotations) {
-				return
-			}
-
-			controller.enqueue(obj, EventLogConf, config.Spec.Selector.Type)
-		},
-		UpdateFunc: func(old, new interface{}) {
-			newConfig := new.(*logconfigv1beta1.LogConfig)
-			oldConfig := old.(*logconfigv1beta1.LogConfig)
-			if newConfig.ResourceVersion == oldConfig.ResourceVersion {
-				return
-			}
-			if newConfig.Generation == oldConfig.Generation {
-				return
-			}
-
-			if newConfig.Spec.Selector == nil {
-				return
-			}
-			if !controller.belongOfCluster(newConfig.Spec.Selector.Cluster, newConfig.Annotations) {
-				return
-			}
-
-			controller.handleLogConfigSelectorHasChange(newConfig, oldConfig)
-
-			controller.enqueue(new, EventLogConf, newConfig.Spec.Selector.Type)
-		},
-		DeleteFunc: func(obj interface{}) {
-			config, ok := obj.(*logconfigv1beta1.LogConfig)
-			if !ok {
-				return
-			}
-			if config.Spec.Selector == nil {
-				return
-			}
-			if !controller.belongOfCluster(config.Spec.Selector.Cluster, config.Annotations) {
-				return
-			}
-
-			controller.enqueueForDelete(obj, EventLogConf, config.Spec.Selector.Type)
+	logConfigHandler := handlers.EnqueueForType(EventLogConf, logConfigSelectorType, controller.enqueueLogConfig, controller.enqueueForDelete)
+	logConfigHandler.OnUpdate = func(oldObj, newObj metav1.Object) {
+		controller.handleLogConfigSelectorHasChange(newObj.(*logconfigv1beta1.LogConfig), oldObj.(*logconfigv1beta1.LogConfig))
+	}
+	logConfigInformer.Informer().AddEventHandler(handlers.Wrap(logConfigHandler, predicates.And(
+		predicates.BelongsToCluster{Cluster: config.Cluster, ClusterOf: logConfigClusterOf, InjectorAnnotationKey: InjectorAnnotationKey},
+		predicates.GenerationChangedPredicate{},
+	)))
+
+	podHandler := handlers.EnqueueForType(EventPod, podSelectorType, controller.enqueuePod, controller.enqueueForDelete)
+	podInformer.Informer().AddEventHandler(handlers.Wrap(podHandler, predicates.And(
+		predicates.ResourceVersionChangedPredicate{},
+		predicates.Funcs{
+			CreateFunc: func(obj metav1.Object) bool { return helper.IsPodReady(obj.(*corev1.Pod)) },
+			UpdateFunc: func(_, newObj metav1.Object) bool { return helper.IsPodReady(newObj.(*corev1.Pod)) },
 		},
-	})
-
-	podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			po := obj.(*corev1.Pod)
-			if !helper.IsPodReady(po) {
-				return
-			}
-			controller.enqueue(obj, EventPod, logconfigv1beta1.SelectorTypePod)
+	)))
+
+	deploymentHandler := &handlers.TypedHandler{
+		EventType:      "deployment",
+		SelectorTypeFn: allSelectorType,
+		Enqueue: func(obj interface{}, _, _ string) {
+			d := obj.(*appsv1.Deployment)
+			controller.handleWorkloadChange("Deployment", d, d.Spec.Selector)
 		},
-		UpdateFunc: func(old, new interface{}) {
-			newPod := new.(*corev1.Pod)
-			oldPod := old.(*corev1.Pod)
-			if newPod.ResourceVersion == oldPod.ResourceVersion {
-				return
-			}
-			if !helper.IsPodReady(newPod) {
-				return
-			}
-			controller.enqueue(new, EventPod, logconfigv1beta1.SelectorTypePod)
+	}
+	deploymentInformer.Informer().AddEventHandler(handlers.Wrap(deploymentHandler, predicates.GenerationChangedPredicate{}))
+
+	statefulSetHandler := &handlers.TypedHandler{
+		EventType:      "statefulSet",
+		SelectorTypeFn: allSelectorType,
+		Enqueue: func(obj interface{}, _, _ string) {
+			s := obj.(*appsv1.StatefulSet)
+			controller.handleWorkloadChange("StatefulSet", s, s.Spec.Selector)
 		},
-		DeleteFunc: func(obj interface{}) {
-			controller.enqueueForDelete(obj, EventPod, logconfigv1beta1.SelectorTypePod)
+	}
+	statefulSetInformer.Informer().AddEventHandler(handlers.Wrap(statefulSetHandler, predicates.GenerationChangedPredicate{}))
+
+	daemonSetHandler := &handlers.TypedHandler{
+		EventType:      "daemonSet",
+		SelectorTypeFn: allSelectorType,
+		Enqueue: func(obj interface{}, _, _ string) {
+			d := obj.(*appsv1.DaemonSet)
+			controller.handleWorkloadChange("DaemonSet", d, d.Spec.Selector)
 		},
-	})
+	}
+	daemonSetInformer.Informer().AddEventHandler(handlers.Wrap(daemonSetHandler, predicates.GenerationChangedPredicate{}))
 
-	nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			controller.enqueue(obj, EventNode, logconfigv1beta1.SelectorTypeNode)
-		},
-		UpdateFunc: func(old, new interface{}) {
-			newConfig := new.(*corev1.Node)
-			oldConfig := old.(*corev1.Node)
-			if newConfig.ResourceVersion == oldConfig.ResourceVersion {
-				return
-			}
+	nodeHandler := handlers.EnqueueForType(EventNode, nodeSelectorType, controller.enqueue, nil)
+	nodeInformer.Informer().AddEventHandler(handlers.Wrap(nodeHandler, predicates.ResourceVersionChangedPredicate{}))
 
-			controller.enqueue(new, EventNode, logconfigv1beta1.SelectorTypeNode)
-		},
-	})
+	federatedLogConfigHandler := handlers.EnqueueForType(EventFederatedLogConf, allSelectorType, controller.enqueue, controller.enqueueForDelete)
+	federatedLogConfigInformer.Informer().AddEventHandler(handlers.Wrap(federatedLogConfigHandler, predicates.ResourceVersionChangedPredicate{}))
+
+	federatedClusterLogConfigHandler := handlers.EnqueueForType(EventFederatedClusterLogConf, allSelectorType, controller.enqueue, controller.enqueueForDelete)
+	federatedClusterLogConfigInformer.Informer().AddEventHandler(handlers.Wrap(federatedClusterLogConfigHandler, predicates.ResourceVersionChangedPredicate{}))
 
 	return controller
 }
@@ -397,7 +328,7 @@ func (c *Controller) handleLogConfigSelectorHasChange(new *logconfigv1beta1.LogC
 
 	lgcKey := helper.MetaNamespaceKey(old.Namespace, old.Name)
 	switch new.Spec.Selector.Type {
-	case logconfigv1beta1.SelectorTypePod, logconfigv1beta1.SelectorTypeWorkload:
+	case logconfigv1beta1.SelectorTypePod:
 		if !helper.MatchStringMap(new.Spec.Selector.LabelSelector,
 			old.Spec.Selector.LabelSelector) {
 			err = c.handleAllTypesDelete(lgcKey, logconfigv1beta1.SelectorTypePod)
@@ -406,6 +337,15 @@ func (c *Controller) handleLogConfigSelectorHasChange(new *logconfigv1beta1.LogC
 			}
 		}
 
+	case logconfigv1beta1.SelectorTypeWorkload:
+		if !workloadSelectorEqual(new.Spec.Selector.Workload, old.Spec.Selector.Workload) {
+			c.updateWorkloadIndex(lgcKey, old.Spec.Selector.Workload, new.Spec.Selector.Workload)
+			err = c.handleAllTypesDelete(lgcKey, logconfigv1beta1.SelectorTypePod)
+			if err != nil {
+				log.Error("delete %s failed: %s", lgcKey, err)
+			}
+		}
+
 	case logconfigv1beta1.SelectorTypeNode:
 		if !helper.MatchStringMap(new.Spec.Selector.NodeSelector.NodeSelector,
 			old.Spec.Selector.NodeSelector.NodeSelector) {
@@ -447,6 +387,71 @@ func (c *Controller) enqueueForDelete(obj interface{}, eleType string, selectorT
 	c.workqueue.Add(e)
 }
 
+// enqueueLogConfig is the LogConfig informer's EnqueueFunc: besides the usual
+// enqueue, it (re-)registers the LogConfig under its selector.workload so a
+// later pod/workload event can find it.
+func (c *Controller) enqueueLogConfig(obj interface{}, eleType string, selectorType string) {
+	lgc := obj.(*logconfigv1beta1.LogConfig)
+	if lgc.Spec.Selector != nil {
+		c.addToWorkloadIndex(helper.MetaNamespaceKey(lgc.Namespace, lgc.Name), lgc.Spec.Selector.Workload)
+	}
+	c.enqueue(obj, eleType, selectorType)
+}
+
+// enqueuePod is the Pod informer's EnqueueFunc: besides the usual enqueue, it
+// wakes up any LogConfig selecting the workload that owns this pod.
+func (c *Controller) enqueuePod(obj interface{}, eleType string, selectorType string) {
+	c.enqueue(obj, eleType, selectorType)
+	c.enqueueForOwningWorkload(obj.(*corev1.Pod))
+}
+
+// allSelectorType is the SelectorTypeFunc for Sink/Interceptor/Vm, which
+// have no selector of their own and always reconcile against every
+// LogConfig that references them.
+func allSelectorType(metav1.Object) string {
+	return logconfigv1beta1.SelectorTypeAll
+}
+
+func podSelectorType(metav1.Object) string {
+	return logconfigv1beta1.SelectorTypePod
+}
+
+func nodeSelectorType(metav1.Object) string {
+	return logconfigv1beta1.SelectorTypeNode
+}
+
+func logConfigSelectorType(obj metav1.Object) string {
+	lgc := obj.(*logconfigv1beta1.LogConfig)
+	if lgc.Spec.Selector == nil {
+		return ""
+	}
+	return lgc.Spec.Selector.Type
+}
+
+func clusterLogConfigSelectorType(obj metav1.Object) string {
+	clc := obj.(*logconfigv1beta1.ClusterLogConfig)
+	if clc.Spec.Selector == nil {
+		return ""
+	}
+	return clc.Spec.Selector.Type
+}
+
+func logConfigClusterOf(obj metav1.Object) (string, bool) {
+	lgc := obj.(*logconfigv1beta1.LogConfig)
+	if lgc.Spec.Selector == nil {
+		return "", false
+	}
+	return lgc.Spec.Selector.Cluster, true
+}
+
+func clusterLogConfigClusterOf(obj metav1.Object) (string, bool) {
+	clc := obj.(*logconfigv1beta1.ClusterLogConfig)
+	if clc.Spec.Selector == nil {
+		return "", false
+	}
+	return clc.Spec.Selector.Cluster, true
+}
+
 func (c *Controller) Run(stopCh <-chan struct{}, cacheSyncs ...cache.InformerSynced) error {
 	defer utilruntime.HandleCrash()
 	defer c.workqueue.ShutDown()
@@ -464,6 +469,13 @@ func (c *Controller) Run(stopCh <-chan struct{}, cacheSyncs ...cache.InformerSyn
 
 	go wait.Until(c.runWorker, time.Second, stopCh)
 
+	if c.lease != nil {
+		c.lease.run(stopCh)
+	}
+	if c.gc != nil {
+		c.gc.run(stopCh)
+	}
+
 	<-stopCh
 	log.Info("Shutting down kubernetes discovery workers")
 
@@ -539,22 +551,30 @@ func (c *Controller) syncHandler(element Element) error {
 		}
 
 	case EventClusterLogConf:
-		if err = c.reconcileClusterLogConfig(element); err != nil {
+		err = c.reconcileClusterLogConfig(element)
+		if err != nil {
 			if log.IsDebugLevel() {
 				log.Warn("reconcile clusterLogConfig %s err: %+v", element.Key, err)
 			} else {
 				log.Warn("reconcile clusterLogConfig %s err: %v", element.Key, err)
 			}
 		}
+		if statusErr := c.updateClusterLogConfigStatus(element.Key, err); statusErr != nil {
+			log.Warn("update clusterLogConfig %s status err: %v", element.Key, statusErr)
+		}
 
 	case EventLogConf:
-		if err = c.reconcileLogConfig(element); err != nil {
+		err = c.reconcileLogConfig(element)
+		if err != nil {
 			if log.IsDebugLevel() {
 				log.Warn("reconcile logConfig %s err: %+v", element.Key, err)
 			} else {
 				log.Warn("reconcile logConfig %s err: %v", element.Key, err)
 			}
 		}
+		if statusErr := c.updateLogConfigStatus(element.Key, err); statusErr != nil {
+			log.Warn("update logConfig %s status err: %v", element.Key, statusErr)
+		}
 
 	case EventNode:
 		if err = c.reconcileNode(element.Key); err != nil {
@@ -562,20 +582,38 @@ func (c *Controller) syncHandler(element Element) error {
 		}
 
 	case EventSink:
-		if err = c.reconcileSink(element.Key); err != nil {
+		err = c.reconcileSink(element.Key)
+		if err != nil {
 			log.Warn("reconcile sink %s err: %v", element.Key, err)
 		}
+		if statusErr := c.updateSinkStatus(element.Key, err); statusErr != nil {
+			log.Warn("update sink %s status err: %v", element.Key, statusErr)
+		}
 
 	case EventInterceptor:
-		if err = c.reconcileInterceptor(element.Key); err != nil {
+		err = c.reconcileInterceptor(element.Key)
+		if err != nil {
 			log.Warn("reconcile interceptor %s err: %v", element.Key, err)
 		}
+		if statusErr := c.updateInterceptorStatus(element.Key, err); statusErr != nil {
+			log.Warn("update interceptor %s status err: %v", element.Key, statusErr)
+		}
 
 	case EventVm:
 		if err = c.reconcileVm(element.Key); err != nil {
 			log.Warn("reconcile interceptor %s err: %v", element.Key, err)
 		}
 
+	case EventFederatedLogConf:
+		if err = c.reconcileFederatedLogConfig(element.Key); err != nil {
+			log.Warn("reconcile federatedLogConfig %s err: %v", element.Key, err)
+		}
+
+	case EventFederatedClusterLogConf:
+		if err = c.reconcileFederatedClusterLogConfig(element.Key); err != nil {
+			log.Warn("reconcile federatedClusterLogConfig %s err: %v", element.Key, err)
+		}
+
 	default:
 		utilruntime.HandleError(fmt.Errorf("element type: %s not supported", element.Type))
 		return nil
@@ -583,18 +621,3 @@ func (c *Controller) syncHandler(element Element) error {
 
 	return nil
 }
-
-func (c *Controller) belongOfCluster(cluster string, annotations map[string]string) bool {
-	if c.config.Cluster != cluster {
-		return false
-	}
-
-	// If there's a Sidecar-injected annotation, just ignore it
-	if annotations != nil {
-		if _, ok := annotations[InjectorAnnotationKey]; ok {
-			return false
-		}
-	}
-
-	return true
-}
@@ -0,0 +1,395 @@
+/*
+Copyright 2021 Loggie Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/loggie-io/loggie/pkg/core/log"
+	logconfigv1beta1 "github.com/loggie-io/loggie/pkg/discovery/kubernetes/apis/loggie/v1beta1"
+	"github.com/loggie-io/loggie/pkg/discovery/kubernetes/helper"
+)
+
+// reconcileFederatedLogConfig materializes a concrete LogConfig from a
+// FederatedLogConfig's template when this cluster is selected by its
+// placement, and removes the materialized LogConfig once it no longer is.
+// This is the "unified federated object" pattern: template + placement +
+// overrides live in one CR so users manage a single object per pipeline
+// instead of one LogConfig per cluster.
+func (c *Controller) reconcileFederatedLogConfig(key string) error {
+	namespace, name, err := helper.ParseMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	flc, err := c.federatedLogConfigLister.FederatedLogConfigs(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return c.deleteMaterializedLogConfig(namespace, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !c.isPlaced(flc.Spec.Placement) {
+		if err := c.deleteMaterializedLogConfig(namespace, name); err != nil {
+			return err
+		}
+		return c.updateFederatedLogConfigStatus(namespace, name, false)
+	}
+
+	child := materializeLogConfig(flc, c.config.Cluster)
+	if err := applyOverrides(&child.Spec, flc.Spec.Overrides, c.config.Cluster); err != nil {
+		return fmt.Errorf("apply overrides for %s: %w", key, err)
+	}
+
+	if err := c.applyMaterializedLogConfig(child); err != nil {
+		return err
+	}
+	return c.updateFederatedLogConfigStatus(namespace, name, true)
+}
+
+// reconcileFederatedClusterLogConfig is the ClusterLogConfig counterpart of
+// reconcileFederatedLogConfig.
+func (c *Controller) reconcileFederatedClusterLogConfig(key string) error {
+	_, name, err := helper.ParseMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	fclc, err := c.federatedClusterLogConfigLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		return c.deleteMaterializedClusterLogConfig(name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !c.isPlaced(fclc.Spec.Placement) {
+		if err := c.deleteMaterializedClusterLogConfig(name); err != nil {
+			return err
+		}
+		return c.updateFederatedClusterLogConfigStatus(name, false)
+	}
+
+	child := materializeClusterLogConfig(fclc, c.config.Cluster)
+	if err := applyOverrides(&child.Spec, fclc.Spec.Overrides, c.config.Cluster); err != nil {
+		return fmt.Errorf("apply overrides for %s: %w", key, err)
+	}
+
+	if err := c.applyMaterializedClusterLogConfig(child); err != nil {
+		return err
+	}
+	return c.updateFederatedClusterLogConfigStatus(name, true)
+}
+
+// isPlaced reports whether this loggie node's cluster is targeted by a
+// federated object's placement, either by exact name or by the optional
+// cluster label selector.
+func (c *Controller) isPlaced(placement logconfigv1beta1.Placement) bool {
+	for _, cluster := range placement.Clusters {
+		if cluster == c.config.Cluster {
+			return true
+		}
+	}
+
+	if placement.ClusterSelector == nil {
+		return false
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(placement.ClusterSelector)
+	if err != nil {
+		log.Warn("invalid clusterSelector: %v", err)
+		return false
+	}
+
+	return selector.Matches(clusterLabels{"cluster": c.config.Cluster})
+}
+
+// clusterLabels adapts the single known label (this node's cluster name)
+// to labels.Labels so it can be matched against a clusterSelector.
+type clusterLabels map[string]string
+
+func (c clusterLabels) Has(key string) bool   { _, ok := c[key]; return ok }
+func (c clusterLabels) Get(key string) string { return c[key] }
+
+// materializeLogConfig renders the concrete LogConfig this cluster should
+// have from the federated template plus any overrides scoped to it. The
+// child carries FederatedOwnerLabel so it can be found and swept later
+// without a cross-namespace/cross-object ownerReference, and its selector's
+// Cluster is pinned to this cluster so predicates.BelongsToCluster gates on
+// it like any hand-written LogConfig.
+func materializeLogConfig(flc *logconfigv1beta1.FederatedLogConfig, cluster string) *logconfigv1beta1.LogConfig {
+	child := &logconfigv1beta1.LogConfig{
+		ObjectMeta: flc.Spec.Template.Metadata,
+		Spec:       flc.Spec.Template.Spec,
+	}
+	child.Namespace = flc.Namespace
+	if child.Name == "" {
+		child.Name = flc.Name
+	}
+	if child.Spec.Selector != nil {
+		child.Spec.Selector.Cluster = cluster
+	}
+	child.Labels = helper.MergeStringMap(child.Labels, map[string]string{
+		logconfigv1beta1.FederatedOwnerLabel: fmt.Sprintf("%s/%s", flc.Namespace, flc.Name),
+	})
+
+	return child
+}
+
+func materializeClusterLogConfig(fclc *logconfigv1beta1.FederatedClusterLogConfig, cluster string) *logconfigv1beta1.ClusterLogConfig {
+	child := &logconfigv1beta1.ClusterLogConfig{
+		ObjectMeta: fclc.Spec.Template.Metadata,
+		Spec: logconfigv1beta1.ClusterLogConfigSpec{
+			Selector: fclc.Spec.Template.Spec.Selector,
+			Pipeline: fclc.Spec.Template.Spec.Pipeline,
+		},
+	}
+	if child.Name == "" {
+		child.Name = fclc.Name
+	}
+	if child.Spec.Selector != nil {
+		child.Spec.Selector.Cluster = cluster
+	}
+	child.Labels = helper.MergeStringMap(child.Labels, map[string]string{
+		logconfigv1beta1.FederatedOwnerLabel: fclc.Name,
+	})
+
+	return child
+}
+
+func (c *Controller) applyMaterializedLogConfig(child *logconfigv1beta1.LogConfig) error {
+	client := c.logConfigClientset.LoggieV1beta1().LogConfigs(child.Namespace)
+
+	existing, err := client.Get(context.Background(), child.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = client.Create(context.Background(), child, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	updated := existing.DeepCopy()
+	updated.Spec = child.Spec
+	updated.Labels = helper.MergeStringMap(updated.Labels, child.Labels)
+	_, err = client.Update(context.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *Controller) applyMaterializedClusterLogConfig(child *logconfigv1beta1.ClusterLogConfig) error {
+	client := c.logConfigClientset.LoggieV1beta1().ClusterLogConfigs()
+
+	existing, err := client.Get(context.Background(), child.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = client.Create(context.Background(), child, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	updated := existing.DeepCopy()
+	updated.Spec = child.Spec
+	updated.Labels = helper.MergeStringMap(updated.Labels, child.Labels)
+	_, err = client.Update(context.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// deleteMaterializedLogConfig removes the materialized child once the
+// federated object is gone or no longer selects this cluster. It looks the
+// child up by FederatedOwnerLabel rather than assuming it's named like the
+// federated object itself: the template's own metadata.name, when set,
+// overrides that in materializeLogConfig.
+func (c *Controller) deleteMaterializedLogConfig(namespace, name string) error {
+	selector := labels.SelectorFromSet(labels.Set{
+		logconfigv1beta1.FederatedOwnerLabel: fmt.Sprintf("%s/%s", namespace, name),
+	})
+	children, err := c.logConfigLister.LogConfigs(namespace).List(selector)
+	if err != nil {
+		return err
+	}
+
+	client := c.logConfigClientset.LoggieV1beta1().LogConfigs(namespace)
+	for _, child := range children {
+		if err := client.Delete(context.Background(), child.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyOverrides patches spec (a *LogConfigSpec or *ClusterLogConfigSpec) in
+// place with the JSON-patch-style path/value entries scoped to cluster, eg
+// overriding "/pipeline" to point sink.addr at that environment's broker.
+// It round-trips through the generic JSON representation since the two spec
+// types otherwise share no common interface to patch through.
+func applyOverrides(spec interface{}, overrides []logconfigv1beta1.Override, cluster string) error {
+	var scoped []logconfigv1beta1.Override
+	for _, o := range overrides {
+		if o.ClusterName == cluster {
+			scoped = append(scoped, o)
+		}
+	}
+	if len(scoped) == 0 {
+		return nil
+	}
+
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+
+	for _, o := range scoped {
+		var value interface{}
+		if err := json.Unmarshal([]byte(o.Value), &value); err != nil {
+			return fmt.Errorf("override %s: invalid value %q: %w", o.Path, o.Value, err)
+		}
+		if err := setJSONPath(doc, o.Path, value); err != nil {
+			return fmt.Errorf("override %s: %w", o.Path, err)
+		}
+	}
+
+	patched, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(patched, spec)
+}
+
+// setJSONPath sets value at a "/"-separated JSON-patch path, creating
+// intermediate objects as needed.
+func setJSONPath(doc map[string]interface{}, path string, value interface{}) error {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(segments) == 0 || segments[0] == "" {
+		return fmt.Errorf("empty path")
+	}
+
+	cur := doc
+	for _, seg := range segments[:len(segments)-1] {
+		next, ok := cur[seg].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			cur[seg] = next
+		}
+		cur = next
+	}
+	cur[segments[len(segments)-1]] = value
+	return nil
+}
+
+// deleteMaterializedClusterLogConfig is the ClusterLogConfig counterpart of
+// deleteMaterializedLogConfig, also looking the child up by
+// FederatedOwnerLabel instead of assuming its name matches the owner's.
+func (c *Controller) deleteMaterializedClusterLogConfig(name string) error {
+	selector := labels.SelectorFromSet(labels.Set{
+		logconfigv1beta1.FederatedOwnerLabel: name,
+	})
+	children, err := c.clusterLogConfigLister.List(selector)
+	if err != nil {
+		return err
+	}
+
+	client := c.logConfigClientset.LoggieV1beta1().ClusterLogConfigs()
+	for _, child := range children {
+		if err := client.Delete(context.Background(), child.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateFederatedLogConfigStatus records whether this loggie node's cluster
+// currently has a materialized child LogConfig, so MaterializedClusters
+// reflects reality across however many clusters run this federated object
+// instead of staying permanently empty.
+func (c *Controller) updateFederatedLogConfigStatus(namespace, name string, materialized bool) error {
+	flc, err := c.federatedLogConfigLister.FederatedLogConfigs(namespace).Get(name)
+	if err != nil {
+		// Deleted since it was enqueued, nothing to report status on.
+		return nil
+	}
+
+	clusters := setMaterializedCluster(flc.Status.MaterializedClusters, c.config.Cluster, materialized)
+	if reflect.DeepEqual(clusters, flc.Status.MaterializedClusters) {
+		return nil
+	}
+
+	updated := flc.DeepCopy()
+	updated.Status.MaterializedClusters = clusters
+	_, err = c.logConfigClientset.LoggieV1beta1().FederatedLogConfigs(namespace).UpdateStatus(context.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// updateFederatedClusterLogConfigStatus is the ClusterLogConfig counterpart
+// of updateFederatedLogConfigStatus.
+func (c *Controller) updateFederatedClusterLogConfigStatus(name string, materialized bool) error {
+	fclc, err := c.federatedClusterLogConfigLister.Get(name)
+	if err != nil {
+		return nil
+	}
+
+	clusters := setMaterializedCluster(fclc.Status.MaterializedClusters, c.config.Cluster, materialized)
+	if reflect.DeepEqual(clusters, fclc.Status.MaterializedClusters) {
+		return nil
+	}
+
+	updated := fclc.DeepCopy()
+	updated.Status.MaterializedClusters = clusters
+	_, err = c.logConfigClientset.LoggieV1beta1().FederatedClusterLogConfigs().UpdateStatus(context.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// setMaterializedCluster returns clusters with cluster added or removed,
+// leaving the slice untouched (same backing array, same nil-ness) when it's
+// already in the wanted state so the caller's DeepEqual guard can hold.
+func setMaterializedCluster(clusters []string, cluster string, materialized bool) []string {
+	idx := -1
+	for i, cl := range clusters {
+		if cl == cluster {
+			idx = i
+			break
+		}
+	}
+
+	if materialized {
+		if idx >= 0 {
+			return clusters
+		}
+		return append(append([]string{}, clusters...), cluster)
+	}
+
+	if idx < 0 {
+		return clusters
+	}
+	out := make([]string, 0, len(clusters)-1)
+	out = append(out, clusters[:idx]...)
+	out = append(out, clusters[idx+1:]...)
+	return out
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 Loggie Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/loggie-io/loggie/pkg/core/log"
+	"github.com/loggie-io/loggie/pkg/discovery/kubernetes/helper"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DefaultGCInterval is how often gcController sweeps the pipeline indexes
+// for orphans when config.GCInterval isn't set.
+const DefaultGCInterval = 5 * time.Minute
+
+// gcOrphansTotal counts index entries the GC sweep has queued for teardown
+// because their backing pod/node/vm no longer exists, by resource type.
+var gcOrphansTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "loggie_discovery_gc_orphans_total",
+	Help: "Number of orphaned pipeline index entries queued for teardown by the discovery GC sweep.",
+}, []string{"type"})
+
+// gcController periodically reconciles typePodIndex/typeClusterIndex/
+// typeNodeIndex against live lister state, following the pattern of
+// Kubernetes' pod-GC controller: a delete event can be missed across an
+// informer restart or an apiserver disconnect, and the index would
+// otherwise carry a stale entry forever. The sweep is the backstop that
+// catches what the event handlers missed.
+type gcController struct {
+	c *Controller
+}
+
+func newGCController(c *Controller) *gcController {
+	return &gcController{c: c}
+}
+
+func (g *gcController) run(stopCh <-chan struct{}) {
+	interval := g.c.config.GCInterval
+	if interval <= 0 {
+		interval = DefaultGCInterval
+	}
+
+	go wait.Until(g.sweep, interval, stopCh)
+}
+
+// sweep runs one GC pass. It only ever reads the type indexes and the
+// listers; the actual teardown is enqueued onto c.workqueue and runs on
+// runWorker's goroutine through the same reconcilePod/reconcileNode/
+// reconcileVm path a real delete event takes, so the indexes still only
+// ever get mutated from that one goroutine. The workqueue's built-in
+// per-key dedup is what bounds the pass, not a sweep-local worker pool:
+// an orphan still waiting in the queue from a previous tick is not queued
+// twice, and one still being reconciled is queued at most once more.
+func (g *gcController) sweep() {
+	c := g.c
+
+	if c.config.VmMode {
+		g.sweepIndex(c.typeNodeIndex, EventVm, "vm", g.vmMissing)
+		return
+	}
+
+	g.sweepIndex(c.typePodIndex, EventPod, "pod", g.podMissing)
+	g.sweepIndex(c.typeClusterIndex, EventPod, "pod", g.podMissing)
+	g.sweepIndex(c.typeNodeIndex, EventNode, "node", g.nodeMissing)
+}
+
+// typeIndex is the subset of index.LogConfigType*Index that sweepIndex needs:
+// every tracked resource key, and the LogConfigs keyed under it.
+type typeIndex interface {
+	Keys() []string
+	GetLogConfigKeys(resourceKey string) []string
+}
+
+// sweepIndex finds resourceKeys in idx whose backing pod/node/vm is gone and
+// enqueues a synthetic Element for each, exactly the Element a real delete
+// event would have produced. reconcilePod/reconcileNode/reconcileVm already
+// know how to tear down every LogConfig indexed under a missing resource and
+// to mutate idx, so sweepIndex itself never touches idx or calls
+// handleAllTypesDelete directly.
+func (g *gcController) sweepIndex(idx typeIndex, eventType, metricType string, missing func(resourceKey string) bool) {
+	if idx == nil {
+		return
+	}
+
+	for _, resourceKey := range idx.Keys() {
+		if !missing(resourceKey) {
+			continue
+		}
+
+		for _, lgcKey := range idx.GetLogConfigKeys(resourceKey) {
+			g.recordOrphanEvent(lgcKey, metricType, resourceKey)
+		}
+
+		gcOrphansTotal.WithLabelValues(metricType).Inc()
+		log.Info("gc: queuing orphaned %s %s for teardown", metricType, resourceKey)
+		g.c.workqueue.Add(Element{Type: eventType, Key: resourceKey})
+	}
+}
+
+func (g *gcController) podMissing(resourceKey string) bool {
+	namespace, name, err := helper.ParseMetaNamespaceKey(resourceKey)
+	if err != nil {
+		return false
+	}
+
+	_, err = g.c.podsLister.Pods(namespace).Get(name)
+	return apierrors.IsNotFound(err)
+}
+
+func (g *gcController) nodeMissing(resourceKey string) bool {
+	_, err := g.c.nodeLister.Get(resourceKey)
+	return apierrors.IsNotFound(err)
+}
+
+func (g *gcController) vmMissing(resourceKey string) bool {
+	_, err := g.c.vmLister.Get(resourceKey)
+	return apierrors.IsNotFound(err)
+}
+
+// recordOrphanEvent emits a Warning Event on the LogConfig the orphaned
+// resource belonged to, so `kubectl describe` surfaces why its matched
+// resources shrank without a corresponding delete from the user.
+func (g *gcController) recordOrphanEvent(lgcKey, metricType, resourceKey string) {
+	if g.c.record == nil {
+		return
+	}
+
+	namespace, name, err := helper.ParseMetaNamespaceKey(lgcKey)
+	if err != nil {
+		return
+	}
+
+	lgc, err := g.c.logConfigLister.LogConfigs(namespace).Get(name)
+	if err != nil {
+		return
+	}
+
+	g.c.record.Eventf(lgc, corev1.EventTypeWarning, "OrphanResourceRemoved",
+		"Removed orphaned %s %s: no longer present in the cluster", metricType, resourceKey)
+}
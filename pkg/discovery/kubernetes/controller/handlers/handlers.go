@@ -0,0 +1,111 @@
+/*
+Copyright 2021 Loggie Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package handlers turns an enqueue func and a selector-type func into a
+// cache.ResourceEventHandler, optionally gated by a predicates.Predicate.
+// It exists so NewController's six near-identical
+// cache.ResourceEventHandlerFuncs blocks collapse to one call each.
+package handlers
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/loggie-io/loggie/pkg/discovery/kubernetes/controller/predicates"
+)
+
+// EnqueueFunc adds one item to the controller's workqueue.
+type EnqueueFunc func(obj interface{}, eventType, selectorType string)
+
+// SelectorTypeFunc derives the Element.SelectorType to enqueue with from the
+// object an event fired for, eg reading LogConfig.Spec.Selector.Type.
+type SelectorTypeFunc func(obj metav1.Object) string
+
+// TypedHandler is the data a single informer registration needs: what
+// Element.Type to enqueue as, how to derive the selector type, and how to
+// enqueue (add vs. delete use different cache key funcs upstream).
+type TypedHandler struct {
+	EventType      string
+	SelectorTypeFn SelectorTypeFunc
+	Enqueue        EnqueueFunc
+	EnqueueDelete  EnqueueFunc
+
+	// OnUpdate, if set, runs before Enqueue on a passing Update event - for
+	// side effects like handleLogConfigSelectorHasChange that have to see
+	// both the old and new object, not just the one being enqueued.
+	OnUpdate func(oldObj, newObj metav1.Object)
+}
+
+// EnqueueForType builds a TypedHandler for the given Element.Type.
+func EnqueueForType(eventType string, selectorTypeFn SelectorTypeFunc, enqueue, enqueueDelete EnqueueFunc) *TypedHandler {
+	return &TypedHandler{
+		EventType:      eventType,
+		SelectorTypeFn: selectorTypeFn,
+		Enqueue:        enqueue,
+		EnqueueDelete:  enqueueDelete,
+	}
+}
+
+// Wrap turns h into a cache.ResourceEventHandlerFuncs that only enqueues
+// when p allows the event through.
+func Wrap(h *TypedHandler, p predicates.Predicate) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			mo, ok := obj.(metav1.Object)
+			if !ok || !p.Create(mo) {
+				return
+			}
+			h.Enqueue(obj, h.EventType, h.SelectorTypeFn(mo))
+		},
+		UpdateFunc: func(old, new interface{}) {
+			oldMo, ok1 := old.(metav1.Object)
+			newMo, ok2 := new.(metav1.Object)
+			if !ok1 || !ok2 || !p.Update(oldMo, newMo) {
+				return
+			}
+			if h.OnUpdate != nil {
+				h.OnUpdate(oldMo, newMo)
+			}
+			h.Enqueue(new, h.EventType, h.SelectorTypeFn(newMo))
+		},
+		DeleteFunc: func(obj interface{}) {
+			mo, ok := asMetaObject(obj)
+			if !ok || !p.Delete(mo) {
+				return
+			}
+			if h.EnqueueDelete == nil {
+				return
+			}
+			h.EnqueueDelete(obj, h.EventType, h.SelectorTypeFn(mo))
+		},
+	}
+}
+
+// asMetaObject unwraps a cache.DeletedFinalStateUnknown tombstone before the
+// metav1.Object assertion, mirroring
+// cache.DeletionHandlingMetaNamespaceKeyFunc: a delete missed by the watch
+// and caught on relist arrives wrapped this way, not as the object itself.
+func asMetaObject(obj interface{}) (metav1.Object, bool) {
+	if mo, ok := obj.(metav1.Object); ok {
+		return mo, true
+	}
+	tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+	if !ok {
+		return nil, false
+	}
+	mo, ok := tombstone.Obj.(metav1.Object)
+	return mo, ok
+}
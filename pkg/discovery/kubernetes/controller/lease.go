@@ -0,0 +1,226 @@
+/*
+Copyright 2021 Loggie Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/loggie-io/loggie/pkg/core/log"
+	logconfigv1beta1 "github.com/loggie-io/loggie/pkg/discovery/kubernetes/apis/loggie/v1beta1"
+)
+
+const (
+	// DefaultLeaseDurationSeconds is used when config.LeaseDurationSeconds is unset.
+	DefaultLeaseDurationSeconds = 40
+
+	leaseHolderIdentityPrefix = "loggie/"
+)
+
+// leaseController renews a coordination.k8s.io/v1 Lease named after the
+// local node so the control plane (or an operator) can tell "no Loggie
+// installed" apart from "Loggie crashed", the same way kubelet node leases
+// let the node controller distinguish NotReady from a missing kubelet.
+type leaseController struct {
+	c *Controller
+
+	namespace string
+	leaseName string
+	duration  int32
+}
+
+// newLeaseController builds the lease renewer for this Controller. The lease
+// lives in config.LeaseNamespace, defaulting to kube-node-lease in pod mode
+// and loggie-system in VmMode; it is owned by the Node in pod mode and by
+// the Vm in VmMode.
+func newLeaseController(c *Controller) *leaseController {
+	namespace := c.config.LeaseNamespace
+	if namespace == "" {
+		if c.config.VmMode {
+			namespace = "loggie-system"
+		} else {
+			namespace = "kube-node-lease"
+		}
+	}
+
+	duration := c.config.LeaseDurationSeconds
+	if duration <= 0 {
+		duration = DefaultLeaseDurationSeconds
+	}
+
+	return &leaseController{
+		c:         c,
+		namespace: namespace,
+		leaseName: c.config.NodeName,
+		duration:  duration,
+	}
+}
+
+// run renews the lease every duration/4 until stopCh closes, creating it
+// first if it doesn't exist yet.
+func (l *leaseController) run(stopCh <-chan struct{}) {
+	if err := l.createOrRenew(); err != nil {
+		log.Warn("create lease %s/%s failed: %v", l.namespace, l.leaseName, err)
+	}
+
+	interval := time.Duration(l.duration) * time.Second / 4
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	go wait.Until(func() {
+		if err := l.createOrRenew(); err != nil {
+			log.Warn("renew lease %s/%s failed: %v", l.namespace, l.leaseName, err)
+		}
+	}, interval, stopCh)
+
+	go l.watchForExternalDelete(stopCh)
+}
+
+// watchForExternalDelete keeps a lightweight informer on just this one Lease
+// so a manual `kubectl delete lease` is noticed and repaired immediately
+// instead of waiting up to interval for the next renew tick.
+func (l *leaseController) watchForExternalDelete(stopCh <-chan struct{}) {
+	nameSelector := fields.OneTermEqualSelector("metadata.name", l.leaseName).String()
+
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = nameSelector
+			return l.c.kubeClientset.CoordinationV1().Leases(l.namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = nameSelector
+			return l.c.kubeClientset.CoordinationV1().Leases(l.namespace).Watch(context.Background(), options)
+		},
+	}
+
+	_, informer := cache.NewInformer(lw, &coordinationv1.Lease{}, 0, cache.ResourceEventHandlerFuncs{
+		DeleteFunc: l.onLeaseDeleted,
+	})
+
+	informer.Run(stopCh)
+}
+
+func (l *leaseController) createOrRenew() error {
+	leases := l.c.kubeClientset.CoordinationV1().Leases(l.namespace)
+
+	existing, err := leases.Get(context.Background(), l.leaseName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return l.create()
+	}
+	if err != nil {
+		return err
+	}
+
+	updated := existing.DeepCopy()
+	now := metav1.NowMicro()
+	updated.Spec.RenewTime = &now
+	updated.Spec.LeaseDurationSeconds = &l.duration
+	updated.Spec.HolderIdentity = pointerString(leaseHolderIdentityPrefix + l.c.config.NodeName)
+
+	_, err = leases.Update(context.Background(), updated, metav1.UpdateOptions{})
+	l.updateHeartbeatStatus(err)
+	return err
+}
+
+func (l *leaseController) create() error {
+	now := metav1.NowMicro()
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            l.leaseName,
+			Namespace:       l.namespace,
+			OwnerReferences: l.ownerReferences(),
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       pointerString(leaseHolderIdentityPrefix + l.c.config.NodeName),
+			LeaseDurationSeconds: &l.duration,
+			RenewTime:            &now,
+		},
+	}
+
+	_, err := l.c.kubeClientset.CoordinationV1().Leases(l.namespace).Create(context.Background(), lease, metav1.CreateOptions{})
+	l.updateHeartbeatStatus(err)
+	return err
+}
+
+// ownerReferences ties the Lease's lifecycle to whatever owns it (the Vm CR
+// in VmMode, the Node in pod mode), so it's garbage collected automatically
+// once that parent is deleted.
+func (l *leaseController) ownerReferences() []metav1.OwnerReference {
+	if l.c.config.VmMode && l.c.vmInfo != nil {
+		return []metav1.OwnerReference{
+			*metav1.NewControllerRef(l.c.vmInfo, logconfigv1beta1.SchemeGroupVersion.WithKind("Vm")),
+		}
+	}
+	if l.c.nodeInfo != nil {
+		return []metav1.OwnerReference{
+			*metav1.NewControllerRef(l.c.nodeInfo, corev1.SchemeGroupVersion.WithKind("Node")),
+		}
+	}
+	return nil
+}
+
+// onLeaseDeleted reacts to an externally deleted Lease by recreating it
+// immediately instead of waiting for the next renew tick.
+func (l *leaseController) onLeaseDeleted(obj interface{}) {
+	lease, ok := obj.(*coordinationv1.Lease)
+	if !ok {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			lease, ok = tombstone.Obj.(*coordinationv1.Lease)
+			if !ok {
+				return
+			}
+		} else {
+			return
+		}
+	}
+
+	if lease.Namespace != l.namespace || lease.Name != l.leaseName {
+		return
+	}
+
+	log.Warn("lease %s/%s was deleted externally, recreating", l.namespace, l.leaseName)
+	if err := l.create(); err != nil {
+		log.Warn("recreate lease %s/%s failed: %v", l.namespace, l.leaseName, err)
+	}
+}
+
+// updateHeartbeatStatus is only meaningful in VmMode, where Vm.Status is the
+// place operators look to see whether the agent is alive.
+func (l *leaseController) updateHeartbeatStatus(err error) {
+	if !l.c.config.VmMode || l.c.vmInfo == nil {
+		return
+	}
+
+	if statusErr := l.c.updateVmHeartbeatStatus(l.c.vmInfo.Name, err); statusErr != nil {
+		log.Warn("update vm %s heartbeat status failed: %v", l.c.vmInfo.Name, statusErr)
+	}
+}
+
+func pointerString(s string) *string {
+	return &s
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2021 Loggie Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package predicates decides whether an informer event is worth reacting
+// to, modeled on sigs.k8s.io/controller-runtime/pkg/predicate. It replaces
+// the hand-written belongOfCluster/ResourceVersion/Generation checks that
+// used to be duplicated inline in every AddEventHandler block in
+// NewController.
+package predicates
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Predicate decides whether Create/Update/Delete informer callbacks should
+// be allowed through to the underlying handler.
+type Predicate interface {
+	Create(obj metav1.Object) bool
+	Update(oldObj, newObj metav1.Object) bool
+	Delete(obj metav1.Object) bool
+}
+
+// Funcs lets callers build a Predicate from a subset of functions; an unset
+// func defaults to allowing the event through, same as controller-runtime.
+type Funcs struct {
+	CreateFunc func(obj metav1.Object) bool
+	UpdateFunc func(oldObj, newObj metav1.Object) bool
+	DeleteFunc func(obj metav1.Object) bool
+}
+
+func (f Funcs) Create(obj metav1.Object) bool {
+	if f.CreateFunc == nil {
+		return true
+	}
+	return f.CreateFunc(obj)
+}
+
+func (f Funcs) Update(oldObj, newObj metav1.Object) bool {
+	if f.UpdateFunc == nil {
+		return true
+	}
+	return f.UpdateFunc(oldObj, newObj)
+}
+
+func (f Funcs) Delete(obj metav1.Object) bool {
+	if f.DeleteFunc == nil {
+		return true
+	}
+	return f.DeleteFunc(obj)
+}
+
+// ResourceVersionChangedPredicate ignores updates that are just an informer
+// resync of an object that hasn't actually changed.
+type ResourceVersionChangedPredicate struct{}
+
+func (ResourceVersionChangedPredicate) Create(metav1.Object) bool { return true }
+func (ResourceVersionChangedPredicate) Delete(metav1.Object) bool { return true }
+func (ResourceVersionChangedPredicate) Update(oldObj, newObj metav1.Object) bool {
+	return oldObj.GetResourceVersion() != newObj.GetResourceVersion()
+}
+
+// GenerationChangedPredicate ignores updates that only touch .metadata or
+// .status, since those don't bump .metadata.generation.
+type GenerationChangedPredicate struct{}
+
+func (GenerationChangedPredicate) Create(metav1.Object) bool { return true }
+func (GenerationChangedPredicate) Delete(metav1.Object) bool { return true }
+func (GenerationChangedPredicate) Update(oldObj, newObj metav1.Object) bool {
+	return oldObj.GetGeneration() != newObj.GetGeneration()
+}
+
+// LabelChangedPredicate fires only when .metadata.labels changed.
+type LabelChangedPredicate struct{}
+
+func (LabelChangedPredicate) Create(metav1.Object) bool { return true }
+func (LabelChangedPredicate) Delete(metav1.Object) bool { return true }
+func (LabelChangedPredicate) Update(oldObj, newObj metav1.Object) bool {
+	return !reflect.DeepEqual(oldObj.GetLabels(), newObj.GetLabels())
+}
+
+// AnnotationChangedPredicate fires only when .metadata.annotations changed.
+type AnnotationChangedPredicate struct{}
+
+func (AnnotationChangedPredicate) Create(metav1.Object) bool { return true }
+func (AnnotationChangedPredicate) Delete(metav1.Object) bool { return true }
+func (AnnotationChangedPredicate) Update(oldObj, newObj metav1.Object) bool {
+	return !reflect.DeepEqual(oldObj.GetAnnotations(), newObj.GetAnnotations())
+}
+
+// ClusterOf extracts the cluster a Loggie object's selector targets, so
+// BelongsToCluster can work across LogConfig/ClusterLogConfig without a
+// shared interface between them.
+type ClusterOf func(obj metav1.Object) (cluster string, ok bool)
+
+// BelongsToCluster is the Loggie-specific predicate that used to be the
+// hand-written `belongOfCluster` check duplicated in every LogConfig-ish
+// AddEventHandler block: this loggie node only cares about objects whose
+// selector targets its own cluster, and ignores sidecar-injected copies.
+type BelongsToCluster struct {
+	Cluster               string
+	ClusterOf             ClusterOf
+	InjectorAnnotationKey string
+}
+
+func (b BelongsToCluster) applies(obj metav1.Object) bool {
+	cluster, ok := b.ClusterOf(obj)
+	if !ok || cluster != b.Cluster {
+		return false
+	}
+
+	if b.InjectorAnnotationKey != "" {
+		if _, injected := obj.GetAnnotations()[b.InjectorAnnotationKey]; injected {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b BelongsToCluster) Create(obj metav1.Object) bool { return b.applies(obj) }
+func (b BelongsToCluster) Delete(obj metav1.Object) bool { return b.applies(obj) }
+func (b BelongsToCluster) Update(_, newObj metav1.Object) bool {
+	return b.applies(newObj)
+}
+
+// And allows an event through only if every predicate does.
+func And(ps ...Predicate) Predicate { return andPredicate{ps} }
+
+// Or allows an event through if any predicate does.
+func Or(ps ...Predicate) Predicate { return orPredicate{ps} }
+
+type andPredicate struct{ predicates []Predicate }
+
+func (a andPredicate) Create(obj metav1.Object) bool {
+	for _, p := range a.predicates {
+		if !p.Create(obj) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a andPredicate) Update(oldObj, newObj metav1.Object) bool {
+	for _, p := range a.predicates {
+		if !p.Update(oldObj, newObj) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a andPredicate) Delete(obj metav1.Object) bool {
+	for _, p := range a.predicates {
+		if !p.Delete(obj) {
+			return false
+		}
+	}
+	return true
+}
+
+type orPredicate struct{ predicates []Predicate }
+
+func (o orPredicate) Create(obj metav1.Object) bool {
+	for _, p := range o.predicates {
+		if p.Create(obj) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o orPredicate) Update(oldObj, newObj metav1.Object) bool {
+	for _, p := range o.predicates {
+		if p.Update(oldObj, newObj) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o orPredicate) Delete(obj metav1.Object) bool {
+	for _, p := range o.predicates {
+		if p.Delete(obj) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2021 Loggie Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Every UpdateStatus call in this file fails with NotFound against a real
+// apiserver unless the corresponding CRD declares `subresources: {status: {}}` - that
+// manifest isn't part of this package and has to be kept in lockstep
+// wherever this repo generates/maintains its CRD YAML.
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	logconfigv1beta1 "github.com/loggie-io/loggie/pkg/discovery/kubernetes/apis/loggie/v1beta1"
+	"github.com/loggie-io/loggie/pkg/discovery/kubernetes/helper"
+)
+
+// updateLogConfigStatus recomputes the LogConfig's status from the outcome
+// of the syncHandler pass that just ran for it and writes it back through
+// the /status subresource, so `kubectl get logconfig` shows which pods it
+// matched and why a pipeline failed to render. It's a no-op when nothing
+// actually changed, to avoid write amplification on every resync.
+func (c *Controller) updateLogConfigStatus(key string, reconcileErr error) error {
+	namespace, name, err := helper.ParseMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	lgc, err := c.logConfigLister.LogConfigs(namespace).Get(name)
+	if err != nil {
+		// Deleted since it was enqueued, nothing to report status on.
+		return nil
+	}
+
+	newStatus := logconfigv1beta1.LogConfigStatus{
+		ObservedGeneration: lgc.Generation,
+		MatchedResources:   c.matchedResourcesOf(helper.MetaNamespaceKey(namespace, name)),
+		PipelineConfigHash: pipelineConfigHash(lgc.Spec.Pipeline),
+		LastReconcileTime:  lgc.Status.LastReconcileTime,
+		Conditions:         buildConditions(reconcileErr, lgc.Status.Conditions),
+	}
+
+	if reflect.DeepEqual(lgc.Status, newStatus) {
+		return nil
+	}
+	newStatus.LastReconcileTime = metav1.Now()
+
+	updated := lgc.DeepCopy()
+	updated.Status = newStatus
+	_, err = c.logConfigClientset.LoggieV1beta1().LogConfigs(namespace).UpdateStatus(context.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// updateClusterLogConfigStatus is the ClusterLogConfig counterpart of
+// updateLogConfigStatus; ClusterLogConfig has no namespace so it's fetched
+// and updated through the cluster-scoped client instead.
+func (c *Controller) updateClusterLogConfigStatus(key string, reconcileErr error) error {
+	_, name, err := helper.ParseMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	clc, err := c.clusterLogConfigLister.Get(name)
+	if err != nil {
+		return nil
+	}
+
+	newStatus := logconfigv1beta1.ClusterLogConfigStatus{
+		ObservedGeneration: clc.Generation,
+		MatchedResources:   c.matchedResourcesOf(name),
+		PipelineConfigHash: pipelineConfigHash(clc.Spec.Pipeline),
+		LastReconcileTime:  clc.Status.LastReconcileTime,
+		Conditions:         buildConditions(reconcileErr, clc.Status.Conditions),
+	}
+
+	if reflect.DeepEqual(clc.Status, newStatus) {
+		return nil
+	}
+	newStatus.LastReconcileTime = metav1.Now()
+
+	updated := clc.DeepCopy()
+	updated.Status = newStatus
+	_, err = c.logConfigClientset.LoggieV1beta1().ClusterLogConfigs().UpdateStatus(context.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// updateVmHeartbeatStatus bumps Vm.Status.LastHeartbeatTime and its Ready
+// condition every time the lease controller successfully renews the Vm's
+// Lease, so an operator can distinguish "no Loggie installed" (no Vm status
+// at all) from "Loggie crashed" (LastHeartbeatTime stopped advancing).
+func (c *Controller) updateVmHeartbeatStatus(name string, leaseErr error) error {
+	vm, err := c.vmLister.Get(name)
+	if err != nil {
+		return nil
+	}
+
+	updated := vm.DeepCopy()
+	if leaseErr == nil {
+		updated.Status.LastHeartbeatTime = metav1.Now()
+	}
+	updated.Status.Conditions = buildConditions(leaseErr, vm.Status.Conditions)
+
+	if reflect.DeepEqual(vm.Status, updated.Status) {
+		return nil
+	}
+
+	_, err = c.logConfigClientset.LoggieV1beta1().Vms().UpdateStatus(context.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// updateSinkStatus is the Sink counterpart of updateLogConfigStatus; Sinks
+// have no selector to report matched resources for, just liveness.
+func (c *Controller) updateSinkStatus(key string, reconcileErr error) error {
+	namespace, name, err := helper.ParseMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	sink, err := c.sinkLister.Sinks(namespace).Get(name)
+	if err != nil {
+		return nil
+	}
+
+	newStatus := logconfigv1beta1.SinkStatus{
+		ObservedGeneration: sink.Generation,
+		Conditions:         buildConditions(reconcileErr, sink.Status.Conditions),
+	}
+
+	if reflect.DeepEqual(sink.Status, newStatus) {
+		return nil
+	}
+
+	updated := sink.DeepCopy()
+	updated.Status = newStatus
+	_, err = c.logConfigClientset.LoggieV1beta1().Sinks(namespace).UpdateStatus(context.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// updateInterceptorStatus is the Interceptor counterpart of updateSinkStatus.
+func (c *Controller) updateInterceptorStatus(key string, reconcileErr error) error {
+	namespace, name, err := helper.ParseMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	interceptor, err := c.interceptorLister.Interceptors(namespace).Get(name)
+	if err != nil {
+		return nil
+	}
+
+	newStatus := logconfigv1beta1.InterceptorStatus{
+		ObservedGeneration: interceptor.Generation,
+		Conditions:         buildConditions(reconcileErr, interceptor.Status.Conditions),
+	}
+
+	if reflect.DeepEqual(interceptor.Status, newStatus) {
+		return nil
+	}
+
+	updated := interceptor.DeepCopy()
+	updated.Status = newStatus
+	_, err = c.logConfigClientset.LoggieV1beta1().Interceptors(namespace).UpdateStatus(context.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// matchedResourcesOf reports what this loggie node currently has pipelines
+// rendered for under lgcKey, by reading back the type indexes the reconcile
+// functions maintain.
+func (c *Controller) matchedResourcesOf(lgcKey string) []logconfigv1beta1.MatchedResource {
+	var matched []logconfigv1beta1.MatchedResource
+
+	if c.typePodIndex != nil {
+		for _, podKey := range c.typePodIndex.GetBelongK8sResource(lgcKey) {
+			namespace, name, err := helper.ParseMetaNamespaceKey(podKey)
+			if err != nil {
+				continue
+			}
+			var uid string
+			if pod, err := c.podsLister.Pods(namespace).Get(name); err == nil {
+				uid = string(pod.UID)
+			}
+			matched = append(matched, logconfigv1beta1.MatchedResource{Kind: "Pod", Namespace: namespace, Name: name, UID: uid})
+		}
+	}
+
+	if c.typeNodeIndex != nil {
+		kind := "Node"
+		if c.config.VmMode {
+			kind = "Vm"
+		}
+		for _, nodeKey := range c.typeNodeIndex.GetBelongK8sResource(lgcKey) {
+			matched = append(matched, logconfigv1beta1.MatchedResource{Kind: kind, Name: nodeKey})
+		}
+	}
+
+	return matched
+}
+
+// pipelineConfigHash hashes a LogConfig/ClusterLogConfig's Spec.Pipeline, so
+// `kubectl get` can show whether the spec a node last reconciled against has
+// changed without diffing the whole pipeline. It hashes the raw spec field,
+// not the per-node rendered/templated pipeline runtime actually runs, so it
+// can't reveal two nodes diverging after field-pattern templating - only
+// that the underlying LogConfig/ClusterLogConfig spec itself changed.
+func pipelineConfigHash(pipeline string) string {
+	sum := sha256.Sum256([]byte(pipeline))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// buildConditions reports Ready/Progressing/Degraded from the outcome of a
+// single reconcile pass; Progressing is always False since syncHandler runs
+// the whole pass synchronously before status is written. previous is the
+// object's current Conditions, so a condition's LastTransitionTime only
+// advances when its Status actually flips, rather than on every call -
+// otherwise the caller's reflect.DeepEqual write-amplification guard could
+// never hold since a condition's timestamp would always be fresh.
+func buildConditions(reconcileErr error, previous []logconfigv1beta1.Condition) []logconfigv1beta1.Condition {
+	if reconcileErr != nil {
+		return []logconfigv1beta1.Condition{
+			{
+				Type:               logconfigv1beta1.ConditionReady,
+				Status:             corev1.ConditionFalse,
+				Reason:             "ReconcileFailed",
+				Message:            reconcileErr.Error(),
+				LastTransitionTime: transitionTime(previous, logconfigv1beta1.ConditionReady, corev1.ConditionFalse),
+			},
+			{
+				Type:               logconfigv1beta1.ConditionProgressing,
+				Status:             corev1.ConditionFalse,
+				Reason:             "ReconcileFailed",
+				LastTransitionTime: transitionTime(previous, logconfigv1beta1.ConditionProgressing, corev1.ConditionFalse),
+			},
+			{
+				Type:               logconfigv1beta1.ConditionDegraded,
+				Status:             corev1.ConditionTrue,
+				Reason:             "ReconcileFailed",
+				Message:            reconcileErr.Error(),
+				LastTransitionTime: transitionTime(previous, logconfigv1beta1.ConditionDegraded, corev1.ConditionTrue),
+			},
+		}
+	}
+
+	return []logconfigv1beta1.Condition{
+		{
+			Type:               logconfigv1beta1.ConditionReady,
+			Status:             corev1.ConditionTrue,
+			Reason:             "ReconcileSucceeded",
+			LastTransitionTime: transitionTime(previous, logconfigv1beta1.ConditionReady, corev1.ConditionTrue),
+		},
+		{
+			Type:               logconfigv1beta1.ConditionProgressing,
+			Status:             corev1.ConditionFalse,
+			Reason:             "ReconcileSucceeded",
+			LastTransitionTime: transitionTime(previous, logconfigv1beta1.ConditionProgressing, corev1.ConditionFalse),
+		},
+		{
+			Type:               logconfigv1beta1.ConditionDegraded,
+			Status:             corev1.ConditionFalse,
+			Reason:             "ReconcileSucceeded",
+			LastTransitionTime: transitionTime(previous, logconfigv1beta1.ConditionDegraded, corev1.ConditionFalse),
+		},
+	}
+}
+
+// transitionTime returns the LastTransitionTime a condition of condType
+// should carry: the previous one if its Status hasn't changed, or now if
+// this is the first time it's been set or it just flipped.
+func transitionTime(previous []logconfigv1beta1.Condition, condType logconfigv1beta1.ConditionType, status corev1.ConditionStatus) metav1.Time {
+	for _, cond := range previous {
+		if cond.Type == condType {
+			if cond.Status == status {
+				return cond.LastTransitionTime
+			}
+			break
+		}
+	}
+	return metav1.Now()
+}
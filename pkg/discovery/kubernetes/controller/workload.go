@@ -0,0 +1,176 @@
+/*
+Copyright 2021 Loggie Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/loggie-io/loggie/pkg/core/log"
+	logconfigv1beta1 "github.com/loggie-io/loggie/pkg/discovery/kubernetes/apis/loggie/v1beta1"
+	"github.com/loggie-io/loggie/pkg/discovery/kubernetes/helper"
+)
+
+// workloadIndexKey formats the <kind>/<namespace>/<name> key
+// typeWorkloadIndex is keyed by, matching a LogConfig's
+// selector.workload{kind,name,namespace}.
+func workloadIndexKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// ownerWorkloadKey walks pod.OwnerReferences to find the workload that
+// ultimately owns it: directly for StatefulSet/DaemonSet, through the
+// intermediate ReplicaSet for Deployment. Rolling updates commonly bump a
+// workload's generation without touching pod labels, so this lets selecting
+// by workload catch changes that a label-only selector would miss.
+func (c *Controller) ownerWorkloadKey(pod *corev1.Pod) (string, bool) {
+	for _, ref := range pod.OwnerReferences {
+		switch ref.Kind {
+		case "StatefulSet", "DaemonSet":
+			return workloadIndexKey(ref.Kind, pod.Namespace, ref.Name), true
+
+		case "ReplicaSet":
+			rs, err := c.replicaSetLister.ReplicaSets(pod.Namespace).Get(ref.Name)
+			if err != nil {
+				return "", false
+			}
+			for _, rsRef := range rs.OwnerReferences {
+				if rsRef.Kind == "Deployment" {
+					return workloadIndexKey("Deployment", pod.Namespace, rsRef.Name), true
+				}
+			}
+		}
+	}
+
+	return "", false
+}
+
+// enqueueForOwningWorkload enqueues every LogConfig whose selector.workload
+// matches the workload that owns pod, so `selector: {type: workload}` works
+// without the user having to duplicate the workload's label selector.
+func (c *Controller) enqueueForOwningWorkload(pod *corev1.Pod) {
+	if c.typeWorkloadIndex == nil {
+		return
+	}
+
+	key, ok := c.ownerWorkloadKey(pod)
+	if !ok {
+		return
+	}
+
+	c.enqueueLogConfigsForWorkload(key)
+}
+
+func (c *Controller) enqueueLogConfigsForWorkload(workloadKey string) {
+	for _, lgcKey := range c.typeWorkloadIndex.GetLogConfigKeys(workloadKey) {
+		namespace, name, err := helper.ParseMetaNamespaceKey(lgcKey)
+		if err != nil {
+			continue
+		}
+
+		lgc, err := c.logConfigLister.LogConfigs(namespace).Get(name)
+		if err != nil {
+			continue
+		}
+
+		c.enqueue(lgc, EventLogConf, logconfigv1beta1.SelectorTypeWorkload)
+	}
+}
+
+// workloadSelectorEqual reports whether two selector.workload values refer
+// to the same workload.
+func workloadSelectorEqual(a, b *logconfigv1beta1.WorkloadSelector) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// workloadNamespace defaults workload.Namespace to the namespace of the
+// LogConfig keyed by lgcKey: selector.workload.namespace is optional and, for
+// the documented same-namespace usage, left unset, but every real lookup
+// (ownerWorkloadKey, handleWorkloadChange) keys with the workload's actual
+// namespace. Indexing under an empty namespace would never match those.
+func (c *Controller) workloadNamespace(lgcKey string, namespace string) string {
+	if namespace != "" {
+		return namespace
+	}
+	ns, _, err := helper.ParseMetaNamespaceKey(lgcKey)
+	if err != nil {
+		return namespace
+	}
+	return ns
+}
+
+// addToWorkloadIndex registers lgcKey under the workload it selects, if any,
+// so a pod/workload event can look up the LogConfigs it should wake up.
+func (c *Controller) addToWorkloadIndex(lgcKey string, workload *logconfigv1beta1.WorkloadSelector) {
+	if c.typeWorkloadIndex == nil || workload == nil {
+		return
+	}
+
+	namespace := c.workloadNamespace(lgcKey, workload.Namespace)
+	c.typeWorkloadIndex.Add(workloadIndexKey(workload.Kind, namespace, workload.Name), lgcKey)
+}
+
+// updateWorkloadIndex moves lgcKey's workload index entry from old to new
+// when a LogConfig's selector.workload changes.
+func (c *Controller) updateWorkloadIndex(lgcKey string, old, new *logconfigv1beta1.WorkloadSelector) {
+	if c.typeWorkloadIndex == nil {
+		return
+	}
+
+	if old != nil {
+		namespace := c.workloadNamespace(lgcKey, old.Namespace)
+		c.typeWorkloadIndex.Delete(workloadIndexKey(old.Kind, namespace, old.Name), lgcKey)
+	}
+	c.addToWorkloadIndex(lgcKey, new)
+}
+
+// handleWorkloadChange is shared by the Deployment/StatefulSet/DaemonSet
+// informers: it re-triggers any LogConfig selecting this workload directly,
+// and enqueues every pod the workload currently owns, since a rolling update
+// can bump the workload's generation without the pods' labels changing.
+func (c *Controller) handleWorkloadChange(kind string, obj metav1.Object, labelSelector *metav1.LabelSelector) {
+	c.enqueueLogConfigsForWorkload(workloadIndexKey(kind, obj.GetNamespace(), obj.GetName()))
+
+	if labelSelector == nil {
+		return
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		log.Warn("invalid selector on %s %s/%s: %v", kind, obj.GetNamespace(), obj.GetName(), err)
+		return
+	}
+
+	pods, err := c.podsLister.Pods(obj.GetNamespace()).List(selector)
+	if err != nil {
+		log.Warn("list pods for %s %s/%s failed: %v", kind, obj.GetNamespace(), obj.GetName(), err)
+		return
+	}
+
+	for _, pod := range pods {
+		if !helper.IsPodReady(pod) {
+			continue
+		}
+		c.enqueue(pod, EventPod, logconfigv1beta1.SelectorTypeWorkload)
+	}
+}